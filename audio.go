@@ -1,5 +1,10 @@
 package media
 
+import (
+	"io"
+	"time"
+)
+
 ////////////////////////////////////////////////////////////////////////////////
 // TYPES
 
@@ -46,6 +51,16 @@ type AudioSamples interface {
 	Bytes(channel int) []byte
 }
 
+// AudioFrame is a single frame of audio samples with a presentation
+// timestamp, which can be decoded from or written to a Media
+type AudioFrame interface {
+	io.Closer
+	AudioSamples
+
+	// Presentation timestamp for the frame
+	PTS() time.Duration
+}
+
 ////////////////////////////////////////////////////////////////////////////////
 // CONSTANTS
 
@@ -0,0 +1,25 @@
+package media
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// ReaderOptions configures a Media opened from an arbitrary io.Reader via
+// Manager.OpenReader
+type ReaderOptions struct {
+	// Format hints the container format to use, since probing a
+	// non-seekable stream is limited (for example "mp4" or "mpegts").
+	// If empty, ffmpeg will attempt to probe the format from the stream
+	Format string
+
+	// ProbeSize is the size, in bytes, of the buffer used to read and
+	// probe the stream. If zero, a sensible default is used
+	ProbeSize int
+}
+
+// WriterOptions configures a Media created from an arbitrary io.Writer via
+// Manager.CreateWriter
+type WriterOptions struct {
+	// MuxerOptions are passed to the muxer when the header is written
+	// (for example movflags, hls_time)
+	MuxerOptions map[string]any
+}
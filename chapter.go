@@ -0,0 +1,16 @@
+package media
+
+import "time"
+
+// Chapter is a named, timed section of a Media, built from its AVChapter
+// array
+type Chapter struct {
+	// Start and End of the chapter
+	Start, End time.Duration
+
+	// Title of the chapter
+	Title string
+
+	// Language of the title, as an ISO 639 code, if known
+	Language string
+}
@@ -0,0 +1,63 @@
+package media
+
+// StreamDisposition is a bitfield of flags describing the intended use of
+// a Stream, from AVStream.disposition
+type StreamDisposition uint
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	DISPOSITION_DEFAULT          StreamDisposition = (1 << iota) // The default stream of its type
+	DISPOSITION_FORCED                                           // Forced subtitles, always shown
+	DISPOSITION_HEARING_IMPAIRED                                 // Intended for hearing impaired audiences
+	DISPOSITION_ATTACHED_PIC                                     // Stream is a single attached picture (cover art)
+	DISPOSITION_CAPTIONS                                         // Stream contains captions
+	DISPOSITION_LYRICS                                           // Stream contains lyrics
+	DISPOSITION_NONE             StreamDisposition = 0
+	DISPOSITION_MAX                                = DISPOSITION_LYRICS
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (d StreamDisposition) String() string {
+	if d == DISPOSITION_NONE {
+		return d.FlagString()
+	}
+	str := ""
+	for v := StreamDisposition(1); v <= DISPOSITION_MAX; v <<= 1 {
+		if d&v == v {
+			str += "|" + v.FlagString()
+		}
+	}
+	return str[1:]
+}
+
+func (d StreamDisposition) FlagString() string {
+	switch d {
+	case DISPOSITION_NONE:
+		return "DISPOSITION_NONE"
+	case DISPOSITION_DEFAULT:
+		return "DISPOSITION_DEFAULT"
+	case DISPOSITION_FORCED:
+		return "DISPOSITION_FORCED"
+	case DISPOSITION_HEARING_IMPAIRED:
+		return "DISPOSITION_HEARING_IMPAIRED"
+	case DISPOSITION_ATTACHED_PIC:
+		return "DISPOSITION_ATTACHED_PIC"
+	case DISPOSITION_CAPTIONS:
+		return "DISPOSITION_CAPTIONS"
+	case DISPOSITION_LYRICS:
+		return "DISPOSITION_LYRICS"
+	default:
+		return "[?? Invalid StreamDisposition]"
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+
+func (d StreamDisposition) Is(v StreamDisposition) bool {
+	return d&v == v
+}
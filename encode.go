@@ -0,0 +1,92 @@
+package media
+
+import "time"
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// PixelFormat specifies the layout of a single decoded or to-be-encoded
+// video frame
+type PixelFormat uint
+
+// StreamParams describes the codec and muxer parameters used when adding
+// a new output stream with Media.AddStream. Exactly one of Video, Audio
+// or Subtitle should be populated, matching the flags of the Codec passed
+// to AddStream
+type StreamParams struct {
+	Video    *VideoParams
+	Audio    *AudioParams
+	Subtitle *SubtitleParams
+}
+
+// VideoParams are the per-stream encoder parameters for a video stream
+type VideoParams struct {
+	Width, Height int
+	PixelFormat   PixelFormat
+	FrameRate     float64
+	BitRate       int
+	GOPSize       int
+	Profile       string
+	Level         int
+}
+
+// AudioParams are the per-stream encoder parameters for an audio stream
+type AudioParams struct {
+	Format  AudioFormat
+	BitRate int
+}
+
+// SubtitleParams are the per-stream parameters for a subtitle stream
+type SubtitleParams struct {
+	Language string
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// INTERFACES
+
+// Frame is a single raw (unencoded) audio or video frame, either decoded
+// from a Media or ready to be passed to Media.WriteFrame for encoding
+type Frame interface {
+	// Stream the frame is associated with
+	Stream() Stream
+
+	// Presentation timestamp for the frame
+	PTS() time.Duration
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	PIXEL_FORMAT_NONE PixelFormat = iota
+	PIXEL_FORMAT_YUV420P
+	PIXEL_FORMAT_YUV422P
+	PIXEL_FORMAT_YUV444P
+	PIXEL_FORMAT_NV12
+	PIXEL_FORMAT_RGBA
+	PIXEL_FORMAT_RGB24
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (v PixelFormat) String() string {
+	switch v {
+	case PIXEL_FORMAT_NONE:
+		return "PIXEL_FORMAT_NONE"
+	case PIXEL_FORMAT_YUV420P:
+		return "PIXEL_FORMAT_YUV420P"
+	case PIXEL_FORMAT_YUV422P:
+		return "PIXEL_FORMAT_YUV422P"
+	case PIXEL_FORMAT_YUV444P:
+		return "PIXEL_FORMAT_YUV444P"
+	case PIXEL_FORMAT_NV12:
+		return "PIXEL_FORMAT_NV12"
+	case PIXEL_FORMAT_RGBA:
+		return "PIXEL_FORMAT_RGBA"
+	case PIXEL_FORMAT_RGB24:
+		return "PIXEL_FORMAT_RGB24"
+	default:
+		return "[?? Invalid PixelFormat value]"
+	}
+}
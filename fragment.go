@@ -0,0 +1,59 @@
+package media
+
+import (
+	"io"
+	"time"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// CMAFBrand is the brand advertised in the ftyp/styp boxes of a fragmented
+// MP4 / CMAF output
+type CMAFBrand string
+
+// FragmentOptions controls fragmented MP4 / CMAF output, suitable for
+// low-latency streaming formats such as DASH and LL-HLS
+type FragmentOptions struct {
+	// Target duration of each fragment, passed to the muxer as
+	// frag_duration
+	FragmentDuration time.Duration
+
+	// Minimum fragment duration, passed to the muxer as min_frag_duration.
+	// Neither duration affects where segments are cut for OnSegment and
+	// SegmentTemplate: a segment ends at every video keyframe
+	MinFragmentDuration time.Duration
+
+	// CMAF brand to advertise in the ftyp/styp boxes
+	Brand CMAFBrand
+
+	// Emit a styp box at the start of every fragment
+	EmitStyp bool
+
+	// If set, the ftyp+moov init segment is also written to this writer. It
+	// still prefixes the continuous destination file, when there is one
+	// (SegmentTemplate unset). If unset and SegmentTemplate is also
+	// set (no continuous destination file), the init segment has nowhere
+	// to go and is discarded
+	InitSegment io.Writer
+
+	// If set, output is split into independent segment files on disk using
+	// this template (for example "segment-%d.m4s") rather than written as
+	// one continuous fragmented file. The init segment is not written as
+	// part of this sequence - set InitSegment to capture it
+	SegmentTemplate string
+
+	// OnSegment, if set, is called after each fragment has been written,
+	// so that callers can push segments to a CDN/HTTP origin
+	OnSegment func(index int, pts time.Duration, data io.Reader)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	CMAF_BRAND_NONE CMAFBrand = ""     // No CMAF brand, plain fragmented MP4
+	CMAF_BRAND_CMFC CMAFBrand = "cmfc" // CMAF brand for audio+video tracks
+	CMAF_BRAND_CMF2 CMAFBrand = "cmf2" // CMAF brand, second edition
+	CMAF_BRAND_ISO6 CMAFBrand = "iso6" // ISOBMFF base media, sixth edition
+)
@@ -0,0 +1,52 @@
+package media
+
+// HWDeviceType identifies a hardware acceleration backend used to decode
+// (or transfer) video frames on a GPU or other dedicated device
+type HWDeviceType uint
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	HWDEVICE_NONE HWDeviceType = iota
+	HWDEVICE_VAAPI
+	HWDEVICE_VIDEOTOOLBOX
+	HWDEVICE_CUDA
+	HWDEVICE_D3D11VA
+	HWDEVICE_QSV
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// INTERFACES
+
+// HWFrame is a VideoFrame whose pixel data resides on a hardware device
+// rather than in system memory
+type HWFrame interface {
+	VideoFrame
+
+	// Map returns the native handle for the frame's hardware surface, for
+	// example a VASurfaceID, CVPixelBufferRef or CUdeviceptr
+	Map() any
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (t HWDeviceType) String() string {
+	switch t {
+	case HWDEVICE_NONE:
+		return "HWDEVICE_NONE"
+	case HWDEVICE_VAAPI:
+		return "HWDEVICE_VAAPI"
+	case HWDEVICE_VIDEOTOOLBOX:
+		return "HWDEVICE_VIDEOTOOLBOX"
+	case HWDEVICE_CUDA:
+		return "HWDEVICE_CUDA"
+	case HWDEVICE_D3D11VA:
+		return "HWDEVICE_D3D11VA"
+	case HWDEVICE_QSV:
+		return "HWDEVICE_QSV"
+	default:
+		return "[?? Invalid HWDeviceType value]"
+	}
+}
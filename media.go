@@ -20,6 +20,13 @@ type MediaKey string
 // the decode should be terminated.
 type DecodeFn func(context.Context, Packet) error
 
+// DecodeFrameFn is a function which is called for each decoded frame, which
+// is associated with a single stream. The frame may be type-asserted to
+// VideoFrame, AudioFrame or HWFrame depending on the stream and whether
+// hardware acceleration is configured on the Map. The function should
+// return an error if the decode should be terminated
+type DecodeFrameFn func(context.Context, Frame) error
+
 ////////////////////////////////////////////////////////////////////////////////
 // INTERFACES
 
@@ -33,6 +40,18 @@ type Manager interface {
 	// Create media for writing and return it
 	CreateFile(path string) (Media, error)
 
+	// Create fragmented MP4 / CMAF media for writing, suitable for
+	// DASH and LL-HLS, and return it
+	CreateFragmentedFile(path string, opts FragmentOptions) (Media, error)
+
+	// Open media for reading from an arbitrary reader, installing a custom
+	// AVIOContext. If r also implements io.Seeker, seeking is supported
+	OpenReader(r io.Reader, opts ReaderOptions) (Media, error)
+
+	// Create media for writing to an arbitrary writer, in the given
+	// container format, installing a custom AVIOContext
+	CreateWriter(w io.Writer, format string, opts WriterOptions) (Media, error)
+
 	// Create a map of input media. If MediaFlag is MEDIA_FLAG_NONE, then
 	// all streams are mapped, or else a combination of MEDIA_FLAG_AUDIO,
 	// MEDIA_FLAG_VIDEO, MEDIA_FLAG_SUBTITLE and MEDIA_FLAG_DATA
@@ -42,6 +61,17 @@ type Manager interface {
 	// Decode a media file, passing packets to a callback function
 	Decode(context.Context, Map, DecodeFn) error
 
+	// Decode a media file, passing decoded frames to a callback function
+	DecodeFrames(context.Context, Map, DecodeFrameFn) error
+
+	// Return all codecs, or those matching the given flags (combination of
+	// MEDIA_FLAG_AUDIO, MEDIA_FLAG_VIDEO, MEDIA_FLAG_SUBTITLE, MEDIA_FLAG_ENCODER
+	// and MEDIA_FLAG_DECODER)
+	Codecs(MediaFlag) []Codec
+
+	// Return a codec by unique name, or nil if no codec with that name exists
+	CodecByName(string) Codec
+
 	// Log messages from ffmpeg
 	SetDebug(bool)
 }
@@ -53,6 +83,14 @@ type Map interface {
 
 	// Return streams which are mapped for decoding
 	Streams() []Stream
+
+	// Flush any decoder state (codec contexts) for the mapped streams. This
+	// should be called after Media.Seek, otherwise decoding may be corrupted
+	Flush() error
+
+	// SetHWDevice configures hardware-accelerated decoding for the mapped
+	// streams, via the given device type
+	SetHWDevice(HWDeviceType) error
 }
 
 // Media is a source or destination of media
@@ -75,6 +113,38 @@ type Media interface {
 
 	// Return metadata for the media
 	Metadata() Metadata
+
+	// Return chapters for the media, or an empty slice if it has none
+	Chapters() []Chapter
+
+	// Add a stream to the media for encoding, with the given codec and
+	// per-stream parameters, and return it. The media must have been
+	// created with Manager.CreateFile, Manager.CreateFragmentedFile or
+	// Manager.CreateWriter
+	AddStream(Codec, StreamParams) (Stream, error)
+
+	// Write the header for the media, opening the muxer with the given
+	// options (for example "movflags" or "hls_time")
+	WriteHeader(map[string]any) error
+
+	// Write an already-encoded packet to the media
+	WritePacket(Packet) error
+
+	// Encode a frame and write the resulting packet(s) to the media,
+	// rescaling timestamps to the stream timebase
+	WriteFrame(Stream, Frame) error
+
+	// Write the trailer for the media, flushing any buffered data
+	WriteTrailer() error
+
+	// Seek to a target position on a stream. The target is interpreted as a
+	// timestamp relative to the stream timebase, unless SEEK_BYTE or
+	// SEEK_FRAME is set in flags. Map.Flush should be called
+	// afterwards, to avoid decoding corruption
+	Seek(stream Stream, target time.Duration, flags SeekFlag) error
+
+	// Duration of the media, or zero if unknown
+	Duration() time.Duration
 }
 
 // Stream of data multiplexed in the media
@@ -87,6 +157,29 @@ type Stream interface {
 
 	// Return artwork for the stream - if MEDIA_FLAG_ARTWORK is set
 	Artwork() []byte
+
+	// Duration of the stream, or zero if unknown
+	Duration() time.Duration
+
+	// TimeBase returns the numerator and denominator of the stream timebase,
+	// used to interpret frame numbers and raw timestamps
+	TimeBase() (num, den int)
+
+	// Disposition returns the intended use of the stream (default, forced,
+	// captions, attached picture, and so on)
+	Disposition() StreamDisposition
+
+	// Rotation returns the display matrix rotation angle, in degrees, from
+	// the AV_PKT_DATA_DISPLAY_MATRIX side-data, or zero if not present
+	Rotation() float64
+
+	// Stereo3D returns the stereoscopic layout of the stream, or
+	// STEREO3D_NONE if not present
+	Stereo3D() Stereo3DMode
+
+	// Spherical returns the spherical (360) projection of the stream, or
+	// SPHERICAL_NONE if not present
+	Spherical() SphericalProjection
 }
 
 // Metadata embedded in the media
@@ -96,6 +189,20 @@ type Metadata interface {
 
 	// Return value for key
 	Value(MediaKey) any
+
+	// String returns the value for key as a string, and false if the key
+	// is not present
+	String(MediaKey) (string, bool)
+
+	// Int returns the value for key as an integer, and false if the key is
+	// not present or not an integer. Values of the form "5/12" (as used by
+	// MEDIA_KEY_TRACK and MEDIA_KEY_DISC) return the first number
+	Int(MediaKey) (int64, bool)
+
+	// Time returns the value for key as a time.Time, parsed as ISO-8601,
+	// and false if the key is not present or not a valid timestamp. Used
+	// for MEDIA_KEY_CREATED and MEDIA_KEY_PURCHASED
+	Time(MediaKey) (time.Time, bool)
 }
 
 // Packet is a single unit of data in the media
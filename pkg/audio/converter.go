@@ -0,0 +1,199 @@
+package audio
+
+import (
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// AudioConverter converts audio frames between sample rates, sample formats
+// and channel layouts, using libswresample
+type AudioConverter interface {
+	// Convert a frame of input samples, invoking fn once for each frame of
+	// output samples produced. Resampling may produce zero, one or more
+	// output frames for a single input frame, buffering any remainder
+	// internally until enough samples have accumulated
+	Convert(AudioFrame, func(AudioFrame) error) error
+
+	// Flush drains any samples still buffered after the last input frame,
+	// invoking fn for each resulting output frame
+	Flush(func(AudioFrame) error) error
+
+	// Delay returns the number of samples currently buffered internally,
+	// at the output sample rate, so callers can keep PTS in sync
+	Delay() int64
+
+	// Close releases resources associated with the converter
+	Close() error
+}
+
+type converter struct {
+	ctx     *ffmpeg.SwrContext
+	fifo    *ffmpeg.AVAudioFifo
+	in      AudioFormat
+	out     AudioFormat
+	nextpts int64
+}
+
+// Ensure converter complies with AudioConverter interface
+var _ AudioConverter = (*converter)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewAudioConverter creates an AudioConverter which resamples, reformats and
+// remixes audio from the input format to the output format
+func NewAudioConverter(in, out AudioFormat) (AudioConverter, error) {
+	ctx, err := ffmpeg.SWResample_swr_alloc_set_opts2(
+		ffmpeg.AVChannelLayout(out.Layout), ffmpeg.AVSampleFormat(out.Format), int(out.Rate),
+		ffmpeg.AVChannelLayout(in.Layout), ffmpeg.AVSampleFormat(in.Format), int(in.Rate),
+	)
+	if err != nil {
+		return nil, err
+	}
+	if err := ffmpeg.SWResample_swr_init(ctx); err != nil {
+		ffmpeg.SWResample_swr_free(ctx)
+		return nil, err
+	}
+	fifo, err := ffmpeg.AVUtil_av_audio_fifo_alloc(ffmpeg.AVSampleFormat(out.Format), channels(out.Layout))
+	if err != nil {
+		ffmpeg.SWResample_swr_free(ctx)
+		return nil, err
+	}
+	return &converter{ctx: ctx, fifo: fifo, in: in, out: out}, nil
+}
+
+func (conv *converter) Close() error {
+	if conv.fifo != nil {
+		ffmpeg.AVUtil_av_audio_fifo_free(conv.fifo)
+		conv.fifo = nil
+	}
+	if conv.ctx != nil {
+		ffmpeg.SWResample_swr_free(conv.ctx)
+		conv.ctx = nil
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Convert resamples a frame of input samples into the fifo, and emits a
+// frame of output samples for every complete frame accumulated
+func (conv *converter) Convert(in AudioFrame, fn func(AudioFrame) error) error {
+	impl, ok := in.(*audioframe)
+	if !ok || impl == nil {
+		return ErrBadParameter.With("frame")
+	}
+
+	converted, err := ffmpeg.SWResample_swr_convert_frame(conv.ctx, impl.ctx)
+	if err != nil {
+		return err
+	}
+	if err := ffmpeg.AVUtil_av_audio_fifo_write(conv.fifo, converted); err != nil {
+		return err
+	}
+
+	return conv.drain(fn, false)
+}
+
+// Flush drains any samples remaining in the resampler and fifo once the
+// input has been exhausted
+func (conv *converter) Flush(fn func(AudioFrame) error) error {
+	converted, err := ffmpeg.SWResample_swr_convert_frame(conv.ctx, nil)
+	if err != nil {
+		return err
+	}
+	if converted != nil {
+		if err := ffmpeg.AVUtil_av_audio_fifo_write(conv.fifo, converted); err != nil {
+			return err
+		}
+	}
+	return conv.drain(fn, true)
+}
+
+// Delay returns the number of samples currently buffered, at the output
+// sample rate
+func (conv *converter) Delay() int64 {
+	return ffmpeg.SWResample_swr_get_delay(conv.ctx, int64(conv.out.Rate)) +
+		int64(ffmpeg.AVUtil_av_audio_fifo_size(conv.fifo))
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// drain emits complete frames of output samples from the fifo. When flush is
+// true, a final (possibly partial) frame is also emitted
+func (conv *converter) drain(fn func(AudioFrame) error, flush bool) error {
+	const frameSamples = 1024
+	for {
+		size := ffmpeg.AVUtil_av_audio_fifo_size(conv.fifo)
+		if size < frameSamples && !(flush && size > 0) {
+			return nil
+		}
+		n := frameSamples
+		if flush && size < n {
+			n = size
+		}
+		ctx, err := ffmpeg.AVUtil_av_audio_fifo_read(conv.fifo, n)
+		if err != nil {
+			return err
+		}
+		pts := conv.nextpts
+		conv.nextpts += int64(n)
+		frame := newAudioFrame(ctx, samplesToDuration(pts, conv.out.Rate))
+		if err := fn(frame); err != nil {
+			return err
+		}
+	}
+}
+
+// channels returns the number of channels in layout. Every named layout in
+// ChannelLayout is covered explicitly, rather than defaulting unlisted
+// layouts to stereo, since that would mis-size the output fifo
+func channels(layout ChannelLayout) int {
+	switch layout {
+	case CHANNEL_LAYOUT_NONE:
+		return 0
+	case CHANNEL_LAYOUT_MONO:
+		return 1
+	case CHANNEL_LAYOUT_STEREO, CHANNEL_LAYOUT_STEREO_DOWNMIX:
+		return 2
+	case CHANNEL_LAYOUT_2POINT1, CHANNEL_LAYOUT_2_1, CHANNEL_LAYOUT_SURROUND:
+		return 3
+	case CHANNEL_LAYOUT_3POINT1:
+		return 4
+	case CHANNEL_LAYOUT_4POINT0, CHANNEL_LAYOUT_2_2, CHANNEL_LAYOUT_QUAD:
+		return 4
+	case CHANNEL_LAYOUT_4POINT1:
+		return 5
+	case CHANNEL_LAYOUT_5POINT0, CHANNEL_LAYOUT_5POINT0_BACK:
+		return 5
+	case CHANNEL_LAYOUT_5POINT1, CHANNEL_LAYOUT_5POINT1_BACK:
+		return 6
+	case CHANNEL_LAYOUT_6POINT0, CHANNEL_LAYOUT_6POINT0_FRONT, CHANNEL_LAYOUT_HEXAGONAL:
+		return 6
+	case CHANNEL_LAYOUT_6POINT1, CHANNEL_LAYOUT_6POINT1_BACK, CHANNEL_LAYOUT_6POINT1_FRONT:
+		return 7
+	case CHANNEL_LAYOUT_7POINT0, CHANNEL_LAYOUT_7POINT0_FRONT:
+		return 7
+	case CHANNEL_LAYOUT_7POINT1, CHANNEL_LAYOUT_7POINT1_WIDE, CHANNEL_LAYOUT_7POINT1_WIDE_BACK:
+		return 8
+	case CHANNEL_LAYOUT_OCTAGONAL:
+		return 8
+	case CHANNEL_LAYOUT_HEXADECAGONAL:
+		return 16
+	case CHANNEL_LAYOUT_22POINT2:
+		return 24
+	case CHANNEL_LAYOUT_AMBISONIC_FIRST_ORDER:
+		return 4
+	default:
+		return 2
+	}
+}
@@ -0,0 +1,100 @@
+package audio
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type audioframe struct {
+	ctx *ffmpeg.AVFrame
+	pts time.Duration
+}
+
+// Ensure audioframe complies with AudioFrame interface
+var _ AudioFrame = (*audioframe)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewAudioFrame allocates a new audio frame of the given format, with enough
+// capacity for the given duration of samples
+func NewAudioFrame(format AudioFormat, duration time.Duration) (AudioFrame, error) {
+	samples := int(duration.Seconds() * float64(format.Rate))
+	ctx, err := ffmpeg.AVUtil_av_frame_alloc_audio(
+		ffmpeg.AVSampleFormat(format.Format),
+		ffmpeg.AVChannelLayout(format.Layout),
+		int(format.Rate),
+		samples,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &audioframe{ctx: ctx}, nil
+}
+
+func newAudioFrame(ctx *ffmpeg.AVFrame, pts time.Duration) *audioframe {
+	return &audioframe{ctx: ctx, pts: pts}
+}
+
+func (frame *audioframe) Close() error {
+	if frame.ctx != nil {
+		ffmpeg.AVUtil_av_frame_free(frame.ctx)
+		frame.ctx = nil
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (frame *audioframe) Format() AudioFormat {
+	return AudioFormat{
+		Rate:   uint(ffmpeg.AVUtil_av_frame_sample_rate(frame.ctx)),
+		Format: SampleFormat(ffmpeg.AVUtil_av_frame_sample_fmt(frame.ctx)),
+		Layout: ChannelLayout(ffmpeg.AVUtil_av_frame_ch_layout(frame.ctx)),
+	}
+}
+
+func (frame *audioframe) Samples() int {
+	return ffmpeg.AVUtil_av_frame_nb_samples(frame.ctx)
+}
+
+func (frame *audioframe) Channels() int {
+	return ffmpeg.AVUtil_av_frame_channels(frame.ctx)
+}
+
+func (frame *audioframe) Linesize() int {
+	return ffmpeg.AVUtil_av_frame_linesize(frame.ctx, 0)
+}
+
+func (frame *audioframe) Align() bool {
+	return ffmpeg.AVUtil_av_frame_linesize(frame.ctx, 0)%32 == 0
+}
+
+func (frame *audioframe) Bytes(channel int) []byte {
+	return ffmpeg.AVUtil_av_frame_data(frame.ctx, channel)
+}
+
+func (frame *audioframe) PTS() time.Duration {
+	return frame.pts
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// samplesToDuration converts a sample offset at the given rate into a
+// time.Duration
+func samplesToDuration(samples int64, rate uint) time.Duration {
+	if rate == 0 {
+		return 0
+	}
+	return time.Duration(samples) * time.Second / time.Duration(rate)
+}
@@ -0,0 +1,56 @@
+package audio
+
+import (
+	"errors"
+	"io"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type manager struct{}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// New returns a manager for audio conversion
+func New() *manager {
+	return new(manager)
+}
+
+func (m *manager) Close() error {
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// NewAudioConverter returns an AudioConverter which resamples, reformats and
+// remixes audio frames from the input format to the output format
+func (m *manager) NewAudioConverter(in, out AudioFormat) (AudioConverter, error) {
+	return NewAudioConverter(in, out)
+}
+
+// Convert is a convenience method which converts a single frame using a
+// short-lived AudioConverter, invoking fn for each resulting output frame.
+// If fn returns io.EOF, conversion stops without error
+func (m *manager) Convert(frame AudioFrame, out AudioFormat, fn func(AudioFrame) error) error {
+	conv, err := m.NewAudioConverter(frame.Format(), out)
+	if err != nil {
+		return err
+	}
+	defer conv.Close()
+
+	if err := conv.Convert(frame, fn); errors.Is(err, io.EOF) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	if err := conv.Flush(fn); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,87 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// audioframe is an AudioFrame decoded from a Media by DecodeFrames
+type audioframe struct {
+	ctx    *ffmpeg.AVFrame
+	stream Stream
+	pts    time.Duration
+}
+
+// Ensure audioframe complies with AudioFrame interface
+var _ AudioFrame = (*audioframe)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newAudioFrame(ctx *ffmpeg.AVFrame, stream Stream, pts time.Duration) *audioframe {
+	return &audioframe{ctx: ctx, stream: stream, pts: pts}
+}
+
+func (frame *audioframe) Close() error {
+	if frame.ctx != nil {
+		ffmpeg.AVUtil_av_frame_free(frame.ctx)
+		frame.ctx = nil
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (frame *audioframe) Stream() Stream {
+	return frame.stream
+}
+
+func (frame *audioframe) PTS() time.Duration {
+	return frame.pts
+}
+
+func (frame *audioframe) Format() AudioFormat {
+	return AudioFormat{
+		Rate:   uint(ffmpeg.AVUtil_av_frame_sample_rate(frame.ctx)),
+		Format: SampleFormat(ffmpeg.AVUtil_av_frame_sample_fmt(frame.ctx)),
+		Layout: ChannelLayout(ffmpeg.AVUtil_av_frame_ch_layout(frame.ctx)),
+	}
+}
+
+func (frame *audioframe) Samples() int {
+	return ffmpeg.AVUtil_av_frame_nb_samples(frame.ctx)
+}
+
+func (frame *audioframe) Channels() int {
+	return ffmpeg.AVUtil_av_frame_channels(frame.ctx)
+}
+
+func (frame *audioframe) Linesize() int {
+	return ffmpeg.AVUtil_av_frame_linesize(frame.ctx, 0)
+}
+
+func (frame *audioframe) Align() bool {
+	return ffmpeg.AVUtil_av_frame_linesize(frame.ctx, 0)%32 == 0
+}
+
+func (frame *audioframe) Bytes(channel int) []byte {
+	return ffmpeg.AVUtil_av_frame_data(frame.ctx, channel)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// avframe returns the underlying AVFrame, for internal use by the encode
+// path which needs to pass it to cgo calls directly
+func (frame *audioframe) avframe() *ffmpeg.AVFrame {
+	return frame.ctx
+}
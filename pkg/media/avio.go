@@ -0,0 +1,236 @@
+package media
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// avioInput is Media opened for reading from an arbitrary io.Reader, via a
+// custom AVIOContext built from avio_alloc_context
+type avioInput struct {
+	ctx     *ffmpeg.AVFormatContext
+	avio    *ffmpeg.AVIOContext
+	streams []*stream
+	closefn func(Media) error
+}
+
+// Ensure avioInput complies with Media interface
+var _ Media = (*avioInput)(nil)
+
+// Ensure avioInput complies with mediaInput interface
+var _ mediaInput = (*avioInput)(nil)
+
+// avioOutput is Media written to an arbitrary io.Writer, via a custom
+// AVIOContext built from avio_alloc_context
+type avioOutput struct {
+	*output
+	avio *ffmpeg.AVIOContext
+	opts WriterOptions
+}
+
+// Ensure avioOutput complies with Media interface
+var _ Media = (*avioOutput)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// defaultAVIOBufSize is used when ReaderOptions.ProbeSize is not set
+const defaultAVIOBufSize = 4096
+
+// NewInputReader opens media for reading from r. If r also implements
+// io.Seeker the AVIOContext seek callback is wired up, otherwise the
+// context is marked non-seekable, which limits format probing
+func NewInputReader(r io.Reader, opts ReaderOptions, closefn func(Media) error) (Media, error) {
+	bufSize := opts.ProbeSize
+	if bufSize <= 0 {
+		bufSize = defaultAVIOBufSize
+	}
+
+	var seekFn func(int64, int) (int64, error)
+	if seeker, ok := r.(io.Seeker); ok {
+		seekFn = func(offset int64, whence int) (int64, error) {
+			return seeker.Seek(offset, whence)
+		}
+	}
+
+	avio, err := ffmpeg.AVFormat_avio_alloc_context(bufSize, false, r.Read, nil, seekFn)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := ffmpeg.AVFormat_avformat_open_input_avio(avio, opts.Format)
+	if err != nil {
+		ffmpeg.AVFormat_avio_context_free(avio)
+		return nil, err
+	}
+	if err := ffmpeg.AVFormat_avformat_find_stream_info(ctx); err != nil {
+		ffmpeg.AVFormat_avformat_close_input(ctx)
+		ffmpeg.AVFormat_avio_context_free(avio)
+		return nil, err
+	}
+
+	return &avioInput{ctx: ctx, avio: avio, streams: newStreams(ctx), closefn: closefn}, nil
+}
+
+func (in *avioInput) Close() error {
+	var result error
+
+	if in.ctx != nil {
+		if err := ffmpeg.AVFormat_avformat_close_input(in.ctx); err != nil {
+			result = multierror.Append(result, err)
+		}
+		in.ctx = nil
+	}
+	if in.avio != nil {
+		if err := ffmpeg.AVFormat_avio_context_free(in.avio); err != nil {
+			result = multierror.Append(result, err)
+		}
+		in.avio = nil
+	}
+	if in.closefn != nil {
+		if err := in.closefn(in); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result
+}
+
+// NewOutputWriter creates media for writing to w in the given container
+// format, via a non-seekable AVIOContext
+func NewOutputWriter(w io.Writer, format string, opts WriterOptions, closefn func(Media) error) (Media, error) {
+	avio, err := ffmpeg.AVFormat_avio_alloc_context(defaultAVIOBufSize, true, nil, w.Write, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := ffmpeg.AVFormat_avformat_alloc_output_context2_avio(avio, format)
+	if err != nil {
+		ffmpeg.AVFormat_avio_context_free(avio)
+		return nil, err
+	}
+
+	output := newOutput(ctx, closefn)
+	output.url = format + ":pipe:"
+	return &avioOutput{output: output, avio: avio, opts: opts}, nil
+}
+
+// WriteHeader merges WriterOptions.MuxerOptions with the given muxer
+// options, which take precedence, and writes the header
+func (out *avioOutput) WriteHeader(opts map[string]any) error {
+	merged := make(map[string]any, len(out.opts.MuxerOptions)+len(opts))
+	for k, v := range out.opts.MuxerOptions {
+		merged[k] = v
+	}
+	for k, v := range opts {
+		merged[k] = v
+	}
+	return out.output.WriteHeader(merged)
+}
+
+func (out *avioOutput) Close() error {
+	var result error
+
+	if err := out.output.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if out.avio != nil {
+		if err := ffmpeg.AVFormat_avio_context_free(out.avio); err != nil {
+			result = multierror.Append(result, err)
+		}
+		out.avio = nil
+	}
+
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (in *avioInput) URL() string {
+	return "pipe:"
+}
+
+func (in *avioInput) Streams() []Stream {
+	result := make([]Stream, len(in.streams))
+	for i, stream := range in.streams {
+		result[i] = stream
+	}
+	return result
+}
+
+func (in *avioInput) Flags() MediaFlag {
+	flags := MEDIA_FLAG_NONE
+	for _, stream := range in.streams {
+		flags |= stream.Flags()
+	}
+	return flags
+}
+
+func (in *avioInput) Metadata() Metadata {
+	return NewMetadata(in.ctx)
+}
+
+// Chapters behaves as input.Chapters, see chapter.go
+func (in *avioInput) Chapters() []Chapter {
+	return chaptersFromContext(in.ctx)
+}
+
+// Seek behaves as input.Seek, see seek.go
+func (in *avioInput) Seek(s Stream, target time.Duration, flags SeekFlag) error {
+	impl, ok := s.(*stream)
+	if !ok || impl == nil {
+		return ErrBadParameter.With("stream")
+	}
+	return ffmpeg.AVFormat_av_seek_frame(in.ctx, impl.Index(), seekTimestamp(impl, target, flags), avSeekFlags(flags))
+}
+
+// Duration behaves as input.Duration, see seek.go
+func (in *avioInput) Duration() time.Duration {
+	return ffmpeg.AVFormat_duration(in.ctx)
+}
+
+// fmtctx returns the underlying AVFormatContext, so avioInput satisfies
+// mediaInput and can be decoded via Manager.Decode/DecodeFrames just like
+// media opened via OpenFile
+func (in *avioInput) fmtctx() *ffmpeg.AVFormatContext {
+	return in.ctx
+}
+
+// AddStream is not supported on input media - streams are only added to
+// media opened for writing
+func (in *avioInput) AddStream(Codec, StreamParams) (Stream, error) {
+	return nil, errors.New("AddStream: not supported on input media")
+}
+
+// WriteHeader is not supported on input media
+func (in *avioInput) WriteHeader(map[string]any) error {
+	return errors.New("WriteHeader: not supported on input media")
+}
+
+// WritePacket is not supported on input media
+func (in *avioInput) WritePacket(Packet) error {
+	return errors.New("WritePacket: not supported on input media")
+}
+
+// WriteFrame is not supported on input media
+func (in *avioInput) WriteFrame(Stream, Frame) error {
+	return errors.New("WriteFrame: not supported on input media")
+}
+
+// WriteTrailer is not supported on input media
+func (in *avioInput) WriteTrailer() error {
+	return errors.New("WriteTrailer: not supported on input media")
+}
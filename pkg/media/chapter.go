@@ -0,0 +1,53 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Chapters returns the chapters for the input media, built from the
+// AVChapter array
+func (in *input) Chapters() []Chapter {
+	return chaptersFromContext(in.ctx)
+}
+
+// Chapters is not meaningful for output media, which has no AVChapter
+// array until it has been muxed and reopened for reading
+func (out *output) Chapters() []Chapter {
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// chaptersFromContext builds the Chapter list from the AVChapter array of
+// an already-opened format context
+func chaptersFromContext(ctx *ffmpeg.AVFormatContext) []Chapter {
+	avchapters := ffmpeg.AVFormat_chapters(ctx)
+	result := make([]Chapter, len(avchapters))
+	for i, ch := range avchapters {
+		num, den := ffmpeg.AVChapter_time_base(ch)
+		result[i] = Chapter{
+			Start:    rescaleChapterTS(ffmpeg.AVChapter_start(ch), num, den),
+			End:      rescaleChapterTS(ffmpeg.AVChapter_end(ch), num, den),
+			Title:    ffmpeg.AVChapter_metadata_value(ch, "title"),
+			Language: ffmpeg.AVChapter_metadata_value(ch, "language"),
+		}
+	}
+	return result
+}
+
+func rescaleChapterTS(ts int64, num, den int) time.Duration {
+	if den == 0 {
+		return 0
+	}
+	return time.Duration(ts) * time.Duration(num) * time.Second / time.Duration(den)
+}
@@ -0,0 +1,91 @@
+package media
+
+import (
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type codec struct {
+	ctx   *ffmpeg.AVCodec
+	flags MediaFlag
+}
+
+// Ensure codec complies with Codec interface
+var _ Codec = (*codec)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newCodec(ctx *ffmpeg.AVCodec) *codec {
+	codec := new(codec)
+	codec.ctx = ctx
+	if ffmpeg.AVCodec_is_encoder(ctx) {
+		codec.flags |= MEDIA_FLAG_ENCODER
+	}
+	if ffmpeg.AVCodec_is_decoder(ctx) {
+		codec.flags |= MEDIA_FLAG_DECODER
+	}
+	switch ffmpeg.AVCodec_type(ctx) {
+	case ffmpeg.AVMEDIA_TYPE_VIDEO:
+		codec.flags |= MEDIA_FLAG_VIDEO
+	case ffmpeg.AVMEDIA_TYPE_AUDIO:
+		codec.flags |= MEDIA_FLAG_AUDIO
+	case ffmpeg.AVMEDIA_TYPE_SUBTITLE:
+		codec.flags |= MEDIA_FLAG_SUBTITLE
+	}
+	return codec
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (codec *codec) Name() string {
+	return ffmpeg.AVCodec_name(codec.ctx)
+}
+
+func (codec *codec) Description() string {
+	return ffmpeg.AVCodec_long_name(codec.ctx)
+}
+
+func (codec *codec) Flags() MediaFlag {
+	return codec.flags
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// codecs returns every codec known to ffmpeg, filtered by flags (MEDIA_FLAG_NONE
+// returns all codecs)
+func codecs(flags MediaFlag) []Codec {
+	var result []Codec
+	var opaque uintptr
+	for {
+		ctx := ffmpeg.AVCodec_av_codec_iterate(&opaque)
+		if ctx == nil {
+			break
+		}
+		codec := newCodec(ctx)
+		if flags == MEDIA_FLAG_NONE || codec.Flags()&flags == flags {
+			result = append(result, codec)
+		}
+	}
+	return result
+}
+
+// codecByName returns the codec with the given unique name, or nil if no
+// codec with that name is registered
+func codecByName(name string) Codec {
+	if ctx := ffmpeg.AVCodec_avcodec_find_encoder_by_name(name); ctx != nil {
+		return newCodec(ctx)
+	}
+	if ctx := ffmpeg.AVCodec_avcodec_find_decoder_by_name(name); ctx != nil {
+		return newCodec(ctx)
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// DecodeFrames decodes packets from a media file into frames, invoking fn
+// for each decoded frame
+func (manager *manager) DecodeFrames(ctx context.Context, media_map Map, fn DecodeFrameFn) error {
+	var result error
+
+	// Get input
+	input, ok := media_map.Input().(mediaInput)
+	if !ok || input == nil {
+		return ErrBadParameter.With("input")
+	}
+
+	packet := media_map.(*decodemap).Packet().(*packet)
+	if packet == nil {
+		return ErrBadParameter.With("packet")
+	}
+
+FOR_LOOP:
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+			if err := ffmpeg.AVFormat_av_read_frame(input.fmtctx(), packet.ctx); err != nil {
+				if !errors.Is(err, io.EOF) {
+					result = multierror.Append(result, err)
+				}
+				break FOR_LOOP
+			}
+			if err := media_map.(*decodemap).DecodeFrame(ctx, packet, fn); err != nil {
+				result = multierror.Append(result, err)
+				break FOR_LOOP
+			}
+			packet.Release()
+		}
+	}
+
+	if err := media_map.(*decodemap).Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	return result
+}
+
+// DecodeFrame decodes a single packet into zero or more frames for its
+// stream, opening the stream's decoder on first use, and invokes fn for
+// each frame produced
+func (d *decodemap) DecodeFrame(ctx context.Context, pkt *packet, fn DecodeFrameFn) error {
+	s, ok := pkt.Stream().(*stream)
+	if !ok || s == nil {
+		return ErrBadParameter.With("stream")
+	}
+
+	if s.codec == nil {
+		codec, err := openDecoder(s)
+		if err != nil {
+			return err
+		}
+		s.codec = codec
+	}
+
+	if err := ffmpeg.AVCodec_avcodec_send_packet(s.codec, pkt.ctx); err != nil {
+		return err
+	}
+
+	for {
+		frame, err := ffmpeg.AVCodec_avcodec_receive_frame(s.codec)
+		if errors.Is(err, ffmpeg.EAGAIN) || errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		if err := dispatchFrame(ctx, s, frame, timestamp(s, frame), fn); err != nil {
+			return err
+		}
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// openDecoder finds and opens a decoder for the stream's codec parameters,
+// wiring up the stream's hardware device context if one was configured via
+// decodemap.SetHWDevice
+func openDecoder(s *stream) (*ffmpeg.AVCodecContext, error) {
+	codec, err := ffmpeg.AVCodec_avcodec_find_decoder(ffmpeg.AVStream_codec_id(s.ctx))
+	if err != nil {
+		return nil, err
+	}
+	ctx, err := ffmpeg.AVCodec_avcodec_alloc_context3(codec)
+	if err != nil {
+		return nil, err
+	}
+	if err := ffmpeg.AVCodec_avcodec_parameters_to_context(ctx, s.ctx); err != nil {
+		return nil, err
+	}
+	if s.hwDevice != nil {
+		ffmpeg.AVCodec_set_hw_device_ctx(ctx, s.hwDevice)
+	}
+	if err := ffmpeg.AVCodec_avcodec_open2(ctx, codec); err != nil {
+		return nil, err
+	}
+	return ctx, nil
+}
+
+// dispatchFrame wraps a decoded AVFrame as a VideoFrame, AudioFrame or
+// HWFrame depending on the stream type and hardware configuration, and
+// invokes fn
+func dispatchFrame(ctx context.Context, s *stream, frame *ffmpeg.AVFrame, pts time.Duration, fn DecodeFrameFn) error {
+	if !s.flags.Is(MEDIA_FLAG_VIDEO) {
+		af := newAudioFrame(frame, s, pts)
+		defer af.Close()
+		return fn(ctx, af)
+	}
+
+	if s.hwDevice != nil {
+		hw := newHWFrame(frame, s, pts)
+		defer hw.Release()
+		return fn(ctx, hw)
+	}
+
+	vf := newVideoFrame(frame, s, pts)
+	defer vf.Release()
+	return fn(ctx, vf)
+}
+
+// timestamp rescales a frame's presentation timestamp into a time.Duration,
+// using the stream timebase
+func timestamp(s *stream, frame *ffmpeg.AVFrame) time.Duration {
+	num, den := s.TimeBase()
+	if den == 0 {
+		return 0
+	}
+	return time.Duration(ffmpeg.AVUtil_av_frame_pts(frame)) * time.Duration(num) * time.Second / time.Duration(den)
+}
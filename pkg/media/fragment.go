@@ -0,0 +1,220 @@
+package media
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// fragmentedOutput is a Media which writes fragmented MP4 / CMAF, built on
+// top of an ordinary output with movflags configured for fragmentation.
+// Bytes are captured directly from the muxer's AVIO write callback, via
+// fragmentWriter, rather than read back from disk
+type fragmentedOutput struct {
+	*output
+	avio *ffmpeg.AVIOContext
+	w    *fragmentWriter
+	opts FragmentOptions
+}
+
+// Ensure fragmentedOutput complies with Media interface
+var _ Media = (*fragmentedOutput)(nil)
+
+// fragmentWriter is the io.Writer behind the muxer's custom AVIOContext. It
+// accumulates the bytes of the current fragment (or init segment) and
+// dispatches them on flushSegment, so callers never need to re-read the
+// destination
+type fragmentWriter struct {
+	dest      io.WriteCloser // nil when SegmentTemplate splits output into per-segment files
+	opts      FragmentOptions
+	buf       bytes.Buffer
+	segment   int
+	wroteInit bool
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewFragmentedOutput creates fragmented MP4 / CMAF media for writing to
+// the given path
+func NewFragmentedOutput(path string, opts FragmentOptions, closefn func(Media) error) (Media, error) {
+	var dest io.WriteCloser
+	if opts.SegmentTemplate == "" {
+		file, err := os.Create(path)
+		if err != nil {
+			return nil, err
+		}
+		dest = file
+	}
+	w := &fragmentWriter{dest: dest, opts: opts}
+
+	avio, err := ffmpeg.AVFormat_avio_alloc_context(defaultAVIOBufSize, true, nil, w.Write, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, err := ffmpeg.AVFormat_avformat_alloc_output_context2_avio(avio, "mp4")
+	if err != nil {
+		ffmpeg.AVFormat_avio_context_free(avio)
+		return nil, err
+	}
+
+	output := newOutput(ctx, closefn)
+	output.url = path
+	return &fragmentedOutput{output: output, avio: avio, w: w, opts: opts}, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (f *fragmentedOutput) Close() error {
+	var result error
+
+	if err := f.output.Close(); err != nil {
+		result = multierror.Append(result, err)
+	}
+	if f.avio != nil {
+		if err := ffmpeg.AVFormat_avio_context_free(f.avio); err != nil {
+			result = multierror.Append(result, err)
+		}
+		f.avio = nil
+	}
+	if f.w.dest != nil {
+		if err := f.w.dest.Close(); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result
+}
+
+// WriteHeader merges the movflags, CMAF brand and fragment duration options
+// required for fragmented output with any caller-supplied muxer options
+func (f *fragmentedOutput) WriteHeader(opts map[string]any) error {
+	flags := []string{"frag_keyframe", "empty_moov", "default_base_moof"}
+	if f.opts.Brand != CMAF_BRAND_NONE {
+		flags = append(flags, "cmaf")
+	}
+	if f.opts.EmitStyp {
+		flags = append(flags, "frag_custom")
+	}
+
+	merged := map[string]any{
+		"movflags": strings.Join(flags, "+"),
+	}
+	if f.opts.Brand != CMAF_BRAND_NONE {
+		merged["brand"] = string(f.opts.Brand)
+	}
+	if f.opts.FragmentDuration > 0 {
+		merged["frag_duration"] = f.opts.FragmentDuration.Microseconds()
+	}
+	if f.opts.MinFragmentDuration > 0 {
+		merged["min_frag_duration"] = f.opts.MinFragmentDuration.Microseconds()
+	}
+	for k, v := range opts {
+		merged[k] = v
+	}
+	return f.output.WriteHeader(merged)
+}
+
+// WriteFrame flushes the previous fragment on every video keyframe (a
+// fragment boundary under frag_keyframe), then writes the frame. The flush
+// happens first so it only ever contains bytes the muxer has already
+// produced for the prior fragment - the new keyframe itself is written
+// afterwards and accumulates into the next fragment
+func (f *fragmentedOutput) WriteFrame(stream Stream, frame Frame) error {
+	if isVideoKeyFrame(frame) {
+		if err := f.w.flushSegment(frame.PTS()); err != nil {
+			return err
+		}
+	}
+	return f.output.WriteFrame(stream, frame)
+}
+
+// WriteTrailer flushes the final fragment after the trailer has been written
+func (f *fragmentedOutput) WriteTrailer() error {
+	if err := f.output.WriteTrailer(); err != nil {
+		return err
+	}
+	return f.w.flushSegment(0)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// Write implements io.Writer for the muxer's AVIOContext, accumulating
+// bytes for the segment currently being assembled
+func (w *fragmentWriter) Write(p []byte) (int, error) {
+	w.buf.Write(p)
+	return len(p), nil
+}
+
+// flushSegment dispatches the bytes accumulated since the last boundary: the
+// first flush is the ftyp+moov init segment (routed to opts.InitSegment if
+// set), subsequent flushes are fragments (routed to a SegmentTemplate file
+// and/or OnSegment). Bytes are always appended to the continuous
+// destination file too, unless SegmentTemplate splits output into files
+func (w *fragmentWriter) flushSegment(pts time.Duration) error {
+	if w.buf.Len() == 0 {
+		return nil
+	}
+	data := append([]byte(nil), w.buf.Bytes()...)
+	w.buf.Reset()
+
+	if w.dest != nil {
+		if _, err := w.dest.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if !w.wroteInit {
+		w.wroteInit = true
+		if w.opts.InitSegment != nil {
+			_, err := w.opts.InitSegment.Write(data)
+			return err
+		}
+		// No dedicated init writer: the init segment was already appended
+		// to dest above (if any). It is not a media segment, so it must not
+		// be dispatched via SegmentTemplate/OnSegment or counted in the
+		// segment index
+		return nil
+	}
+
+	if w.opts.SegmentTemplate != "" {
+		name := fmt.Sprintf(w.opts.SegmentTemplate, w.segment)
+		file, err := os.Create(name)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		if _, err := file.Write(data); err != nil {
+			return err
+		}
+	}
+
+	if w.opts.OnSegment != nil {
+		w.opts.OnSegment(w.segment, pts, bytes.NewReader(data))
+	}
+	w.segment++
+	return nil
+}
+
+// isVideoKeyFrame reports whether frame is a video key frame, used to
+// detect fragment boundaries under the frag_keyframe movflag
+func isVideoKeyFrame(frame Frame) bool {
+	kf, ok := frame.(interface{ isKeyFrame() bool })
+	return ok && kf.isKeyFrame()
+}
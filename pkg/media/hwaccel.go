@@ -0,0 +1,125 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// hwframe is a VideoFrame whose pixel data resides on a hardware device,
+// decoded by a codec context configured via decodemap.SetHWDevice
+type hwframe struct {
+	*videoframe
+	sw *videoframe // lazily populated system-memory copy, see Planes/Stride
+}
+
+// Ensure hwframe complies with HWFrame interface
+var _ HWFrame = (*hwframe)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newHWFrame(ctx *ffmpeg.AVFrame, stream Stream, pts time.Duration) *hwframe {
+	return &hwframe{videoframe: newVideoFrame(ctx, stream, pts)}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Map returns the native handle for the frame's hardware surface (for
+// example a VASurfaceID, CVPixelBufferRef or CUdeviceptr)
+func (frame *hwframe) Map() any {
+	return ffmpeg.AVUtil_av_frame_hw_native_handle(frame.ctx)
+}
+
+// Planes transfers the frame to system memory via av_hwframe_transfer_data
+// on first access, and returns its planes. Callers that only need the
+// native handle should use Map instead, to avoid the transfer
+func (frame *hwframe) Planes() [][]byte {
+	sw, err := frame.software()
+	if err != nil {
+		return nil
+	}
+	return sw.Planes()
+}
+
+// Stride transfers the frame to system memory via av_hwframe_transfer_data
+// on first access, see Planes
+func (frame *hwframe) Stride(plane int) int {
+	sw, err := frame.software()
+	if err != nil {
+		return 0
+	}
+	return sw.Stride(plane)
+}
+
+// Release frees the hardware frame and any system-memory copy made by
+// Planes or Stride
+func (frame *hwframe) Release() error {
+	if frame.sw != nil {
+		frame.sw.Release()
+		frame.sw = nil
+	}
+	return frame.videoframe.Release()
+}
+
+// software lazily transfers the hardware frame into system memory
+func (frame *hwframe) software() (*videoframe, error) {
+	if frame.sw != nil {
+		return frame.sw, nil
+	}
+	ctx, err := ffmpeg.AVUtil_av_hwframe_transfer_data(frame.ctx)
+	if err != nil {
+		return nil, err
+	}
+	frame.sw = newVideoFrame(ctx, frame.stream, frame.pts)
+	return frame.sw, nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// SetHWDevice
+
+// SetHWDevice configures hardware-accelerated decoding for every mapped
+// video stream, via av_hwdevice_ctx_create. The device is applied when the
+// decoder for each stream is opened, on first use in DecodeFrames
+func (d *decodemap) SetHWDevice(t HWDeviceType) error {
+	if t == HWDEVICE_NONE {
+		return nil
+	}
+	device, err := ffmpeg.AVUtil_av_hwdevice_ctx_create(avHWDeviceType(t))
+	if err != nil {
+		return err
+	}
+	for _, s := range d.Streams() {
+		impl, ok := s.(*stream)
+		if !ok || impl == nil || !impl.flags.Is(MEDIA_FLAG_VIDEO) {
+			continue
+		}
+		impl.hwDevice = device
+	}
+	return nil
+}
+
+func avHWDeviceType(t HWDeviceType) ffmpeg.AVHWDeviceType {
+	switch t {
+	case HWDEVICE_VAAPI:
+		return ffmpeg.AV_HWDEVICE_TYPE_VAAPI
+	case HWDEVICE_VIDEOTOOLBOX:
+		return ffmpeg.AV_HWDEVICE_TYPE_VIDEOTOOLBOX
+	case HWDEVICE_CUDA:
+		return ffmpeg.AV_HWDEVICE_TYPE_CUDA
+	case HWDEVICE_D3D11VA:
+		return ffmpeg.AV_HWDEVICE_TYPE_D3D11VA
+	case HWDEVICE_QSV:
+		return ffmpeg.AV_HWDEVICE_TYPE_QSV
+	default:
+		return ffmpeg.AV_HWDEVICE_TYPE_NONE
+	}
+}
@@ -22,6 +22,23 @@ type manager struct {
 	media map[Media]bool
 }
 
+// mediaInput is implemented by every Media that reads through an
+// AVFormatContext (*input, *avioInput), so the decode loops below can read
+// packets regardless of whether the media was opened via OpenFile or
+// OpenReader
+type mediaInput interface {
+	fmtctx() *ffmpeg.AVFormatContext
+}
+
+// fmtctx returns the underlying AVFormatContext, for use by the decode
+// loops in Decode and DecodeFrames
+func (in *input) fmtctx() *ffmpeg.AVFormatContext {
+	return in.ctx
+}
+
+// Ensure input complies with mediaInput interface
+var _ mediaInput = (*input)(nil)
+
 // Ensure manager complies with Manager interface
 var _ Manager = (*manager)(nil)
 
@@ -89,11 +106,72 @@ func (m *manager) CreateFile(path string) (Media, error) {
 	return media, nil
 }
 
+// Create fragmented MP4 / CMAF media for writing and return it
+func (m *manager) CreateFragmentedFile(path string, opts FragmentOptions) (Media, error) {
+	media, err := NewFragmentedOutput(path, opts, func(media Media) error {
+		delete(m.media, media)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add to map
+	m.media[media] = true
+
+	// Return success
+	return media, nil
+}
+
+// Open media for reading from an arbitrary reader and return it
+func (m *manager) OpenReader(r io.Reader, opts ReaderOptions) (Media, error) {
+	media, err := NewInputReader(r, opts, func(media Media) error {
+		delete(m.media, media)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add to map
+	m.media[media] = true
+
+	// Return success
+	return media, nil
+}
+
+// Create media for writing to an arbitrary writer and return it
+func (m *manager) CreateWriter(w io.Writer, format string, opts WriterOptions) (Media, error) {
+	media, err := NewOutputWriter(w, format, opts, func(media Media) error {
+		delete(m.media, media)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Add to map
+	m.media[media] = true
+
+	// Return success
+	return media, nil
+}
+
 // Create a new map for decoding
 func (m *manager) Map(media Media, flags MediaFlag) (Map, error) {
 	return NewMap(media, flags)
 }
 
+// Return all codecs, or those matching the given flags
+func (m *manager) Codecs(flags MediaFlag) []Codec {
+	return codecs(flags)
+}
+
+// Return a codec by unique name, or nil if no codec with that name exists
+func (m *manager) CodecByName(name string) Codec {
+	return codecByName(name)
+}
+
 // Set the logging function for the manager
 func (manager *manager) SetDebug(debug bool) {
 	if debug {
@@ -108,7 +186,7 @@ func (manager *manager) Decode(ctx context.Context, media_map Map, fn DecodeFn)
 	var result error
 
 	// Get input
-	input, ok := media_map.Input().(*input)
+	input, ok := media_map.Input().(mediaInput)
 	if !ok || input == nil {
 		return ErrBadParameter.With("input")
 	}
@@ -125,7 +203,7 @@ FOR_LOOP:
 		case <-ctx.Done():
 			return ctx.Err()
 		default:
-			if err := ffmpeg.AVFormat_av_read_frame(input.ctx, packet.ctx); err != nil {
+			if err := ffmpeg.AVFormat_av_read_frame(input.fmtctx(), packet.ctx); err != nil {
 				if !errors.Is(err, io.EOF) {
 					result = multierror.Append(result, err)
 				}
@@ -0,0 +1,33 @@
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+	. "github.com/mutablelogic/go-media/pkg/media"
+)
+
+func Test_manager_000(t *testing.T) {
+	assert := assert.New(t)
+	mgr := New()
+	assert.NotNil(mgr)
+
+	media, err := mgr.OpenReader(bytes.NewReader(nil), ReaderOptions{})
+	assert.NoError(err)
+
+	media_map, err := mgr.Map(media, MEDIA_FLAG_VIDEO|MEDIA_FLAG_AUDIO)
+	assert.NoError(err)
+
+	assert.NoError(mgr.DecodeFrames(context.Background(), media_map, func(ctx context.Context, frame Frame) error {
+		t.Log("frame=", frame)
+		return nil
+	}))
+
+	assert.NoError(mgr.Close())
+}
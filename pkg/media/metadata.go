@@ -0,0 +1,105 @@
+package media
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type metadata struct {
+	entries map[MediaKey]string
+}
+
+// Ensure metadata complies with Metadata interface
+var _ Metadata = (*metadata)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewMetadata builds Metadata from the AVDictionary attached to a format
+// context
+func NewMetadata(ctx *ffmpeg.AVFormatContext) *metadata {
+	entries := make(map[MediaKey]string)
+	for _, entry := range ffmpeg.AVUtil_av_dict_entries(ffmpeg.AVFormat_metadata(ctx)) {
+		entries[MediaKey(entry.Key)] = entry.Value
+	}
+	return &metadata{entries: entries}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (m *metadata) Keys() []MediaKey {
+	keys := make([]MediaKey, 0, len(m.entries))
+	for key := range m.entries {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Value returns the value for key, typed according to the MediaKey
+// (string, int64, time.Time or bool), or nil if the key is not present
+func (m *metadata) Value(key MediaKey) any {
+	v, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	switch key {
+	case MEDIA_KEY_CREATED, MEDIA_KEY_PURCHASED:
+		if t, ok := m.Time(key); ok {
+			return t
+		}
+	case MEDIA_KEY_YEAR, MEDIA_KEY_DISC, MEDIA_KEY_TRACK, MEDIA_KEY_SEASON, MEDIA_KEY_EPISODE_ID:
+		if n, ok := m.Int(key); ok {
+			return n
+		}
+	case MEDIA_KEY_COMPILATION, MEDIA_KEY_GAPLESS_PLAYBACK:
+		return v == "1" || strings.EqualFold(v, "true")
+	}
+	return v
+}
+
+func (m *metadata) String(key MediaKey) (string, bool) {
+	v, ok := m.entries[key]
+	return v, ok
+}
+
+// Int parses the value for key as an integer. Values of the form "5/12"
+// (track/disc) return the number before the slash
+func (m *metadata) Int(key MediaKey) (int64, bool) {
+	v, ok := m.entries[key]
+	if !ok {
+		return 0, false
+	}
+	if i := strings.IndexByte(v, '/'); i >= 0 {
+		v = v[:i]
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(v), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return n, true
+}
+
+// Time parses the value for key as an ISO-8601 timestamp
+func (m *metadata) Time(key MediaKey) (time.Time, bool) {
+	v, ok := m.entries[key]
+	if !ok {
+		return time.Time{}, false
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02"} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
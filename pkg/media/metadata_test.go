@@ -0,0 +1,62 @@
+package media
+
+import (
+	"testing"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+func Test_metadata_000(t *testing.T) {
+	assert := assert.New(t)
+	m := &metadata{entries: map[MediaKey]string{
+		MEDIA_KEY_TRACK:   "5/12",
+		MEDIA_KEY_YEAR:    "2024",
+		MEDIA_KEY_CREATED: "2024-03-01T12:30:00Z",
+		MEDIA_KEY_TITLE:   "Episode One",
+	}}
+
+	track, ok := m.Int(MEDIA_KEY_TRACK)
+	assert.True(ok)
+	assert.Equal(int64(5), track)
+
+	year, ok := m.Int(MEDIA_KEY_YEAR)
+	assert.True(ok)
+	assert.Equal(int64(2024), year)
+
+	_, ok = m.Int(MEDIA_KEY_GENRE)
+	assert.False(ok)
+
+	title, ok := m.String(MEDIA_KEY_TITLE)
+	assert.True(ok)
+	assert.Equal("Episode One", title)
+}
+
+func Test_metadata_001(t *testing.T) {
+	assert := assert.New(t)
+	m := &metadata{entries: map[MediaKey]string{
+		MEDIA_KEY_CREATED: "2024-03-01T12:30:00Z",
+	}}
+
+	created, ok := m.Time(MEDIA_KEY_CREATED)
+	assert.True(ok)
+	assert.Equal(2024, created.Year())
+	assert.Equal(3, int(created.Month()))
+	assert.Equal(1, created.Day())
+
+	_, ok = m.Time(MEDIA_KEY_PURCHASED)
+	assert.False(ok)
+}
+
+func Test_metadata_002(t *testing.T) {
+	assert := assert.New(t)
+	m := &metadata{entries: map[MediaKey]string{
+		MEDIA_KEY_COMPILATION: "1",
+	}}
+
+	v := m.Value(MEDIA_KEY_COMPILATION)
+	assert.Equal(true, v)
+}
@@ -0,0 +1,327 @@
+package media
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-media"
+)
+
+// ErrOutOfOrder is returned when the output-side methods are called in
+// the wrong order, for example WriteHeader before AddStream, or
+// WritePacket before WriteHeader
+var ErrOutOfOrder = errors.New("out of order")
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+// avFramer is implemented by the concrete Frame types (videoframe,
+// audioframe, hwframe) to expose the underlying AVFrame for WriteFrame,
+// since the Frame interface itself carries no ffmpeg-specific accessor
+type avFramer interface {
+	avframe() *ffmpeg.AVFrame
+}
+
+// output is a Media which is written to, via the muxing side of ffmpeg
+type output struct {
+	url     string
+	ctx     *ffmpeg.AVFormatContext
+	streams []*stream
+	header  bool
+	closefn func(Media) error
+	ownsPB  bool // true if NewOutputFile opened ctx's AVIOContext and must close it
+}
+
+// Ensure output complies with Media interface
+var _ Media = (*output)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+// NewOutputFile creates media for writing to the given path, guessing the
+// container format from the file extension
+func NewOutputFile(path string, closefn func(Media) error) (Media, error) {
+	ctx, err := ffmpeg.AVFormat_avformat_alloc_output_context2(path)
+	if err != nil {
+		return nil, err
+	}
+
+	// Some muxers (for example the null muxer) don't use file I/O at all -
+	// AVFMT_NOFILE means ctx.pb must be left alone
+	ownsPB := ffmpeg.AVFormat_oformat_flags(ctx)&ffmpeg.AVFMT_NOFILE == 0
+	if ownsPB {
+		if err := ffmpeg.AVFormat_avio_open(ctx, path, ffmpeg.AVIO_FLAG_WRITE); err != nil {
+			ffmpeg.AVFormat_avformat_free_context(ctx)
+			return nil, err
+		}
+	}
+
+	output := newOutput(ctx, closefn)
+	output.url = path
+	output.ownsPB = ownsPB
+	return output, nil
+}
+
+// newOutput wraps an already-allocated output format context, whether
+// created from a path or from a custom AVIOContext
+func newOutput(ctx *ffmpeg.AVFormatContext, closefn func(Media) error) *output {
+	output := new(output)
+	output.ctx = ctx
+	output.closefn = closefn
+	return output
+}
+
+func (output *output) Close() error {
+	var result error
+
+	if output.ctx != nil {
+		if output.ownsPB {
+			if err := ffmpeg.AVFormat_avio_closep(output.ctx); err != nil {
+				result = multierror.Append(result, err)
+			}
+		}
+		if err := ffmpeg.AVFormat_avformat_free_context(output.ctx); err != nil {
+			result = multierror.Append(result, err)
+		}
+		output.ctx = nil
+	}
+
+	if output.closefn != nil {
+		if err := output.closefn(output); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (output *output) URL() string {
+	return output.url
+}
+
+func (output *output) Streams() []Stream {
+	result := make([]Stream, len(output.streams))
+	for i, stream := range output.streams {
+		result[i] = stream
+	}
+	return result
+}
+
+func (output *output) Flags() MediaFlag {
+	flags := MEDIA_FLAG_FILE
+	for _, stream := range output.streams {
+		flags |= stream.Flags()
+	}
+	return flags
+}
+
+func (output *output) Metadata() Metadata {
+	return NewMetadata(output.ctx)
+}
+
+// AddStream adds an output stream for the given codec, configuring the
+// codec context from the video, audio or subtitle parameters
+func (output *output) AddStream(c Codec, params StreamParams) (Stream, error) {
+	impl, ok := c.(*codec)
+	if !ok || impl == nil {
+		return nil, ErrBadParameter.With("codec")
+	}
+	if output.header {
+		return nil, fmt.Errorf("AddStream: %w: WriteHeader already called", ErrOutOfOrder)
+	}
+
+	ctx, err := ffmpeg.AVFormat_avformat_new_stream(output.ctx, impl.ctx)
+	if err != nil {
+		return nil, err
+	}
+	cctx, err := ffmpeg.AVCodec_avcodec_alloc_context3(impl.ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case params.Video != nil:
+		ffmpeg.AVCodec_set_video_params(cctx, toAVVideoParams(params.Video))
+		num, den := videoTimeBase(params.Video)
+		ffmpeg.AVCodec_set_time_base(cctx, num, den)
+		ffmpeg.AVStream_set_time_base(ctx, num, den)
+	case params.Audio != nil:
+		ffmpeg.AVCodec_set_audio_params(cctx, toAVAudioParams(params.Audio))
+		num, den := audioTimeBase(params.Audio)
+		ffmpeg.AVCodec_set_time_base(cctx, num, den)
+		ffmpeg.AVStream_set_time_base(ctx, num, den)
+	case params.Subtitle != nil:
+		// Subtitle streams carry no codec-level encode parameters
+	default:
+		return nil, ErrBadParameter.With("StreamParams")
+	}
+
+	if err := ffmpeg.AVCodec_avcodec_parameters_from_context(ctx, cctx); err != nil {
+		return nil, err
+	}
+
+	stream := &stream{ctx: ctx, codec: cctx, flags: c.Flags()}
+	output.streams = append(output.streams, stream)
+	return stream, nil
+}
+
+// WriteHeader opens the muxer and writes the container header, applying
+// the given muxer options (for example movflags, hls_time)
+func (output *output) WriteHeader(opts map[string]any) error {
+	if output.header {
+		return fmt.Errorf("WriteHeader: %w: already called", ErrOutOfOrder)
+	}
+	dict, err := ffmpeg.AVUtil_av_dict_from_map(opts)
+	if err != nil {
+		return err
+	}
+	if err := ffmpeg.AVFormat_avformat_write_header(output.ctx, dict); err != nil {
+		return err
+	}
+	output.header = true
+	return nil
+}
+
+// WritePacket writes an already-encoded packet to the muxer
+func (output *output) WritePacket(pkt Packet) error {
+	impl, ok := pkt.(*packet)
+	if !ok || impl == nil {
+		return ErrBadParameter.With("packet")
+	}
+	if !output.header {
+		return fmt.Errorf("WritePacket: %w: WriteHeader not called", ErrOutOfOrder)
+	}
+	return ffmpeg.AVFormat_av_interleaved_write_frame(output.ctx, impl.ctx)
+}
+
+// WriteFrame encodes a frame for the given stream and writes the resulting
+// packet(s) to the muxer, rescaling timestamps from the codec to the
+// stream timebase
+func (output *output) WriteFrame(s Stream, frame Frame) error {
+	impl, ok := s.(*stream)
+	if !ok || impl == nil {
+		return ErrBadParameter.With("stream")
+	}
+	af, ok := frame.(avFramer)
+	if !ok || af == nil {
+		return ErrBadParameter.With("frame")
+	}
+	if !output.header {
+		return fmt.Errorf("WriteFrame: %w: WriteHeader not called", ErrOutOfOrder)
+	}
+
+	if err := ffmpeg.AVCodec_avcodec_send_frame(impl.codec, af.avframe()); err != nil {
+		return fmt.Errorf("WriteFrame: %w", err)
+	}
+	return output.writePackets(impl)
+}
+
+// WriteTrailer flushes any buffered data and writes the container trailer.
+// Encoders with delay (B-frames, lookahead) hold packets back until a nil
+// frame is sent, so every stream's encoder is drained before the trailer
+// is written, otherwise the trailing GOP would be silently dropped
+func (output *output) WriteTrailer() error {
+	if !output.header {
+		return fmt.Errorf("WriteTrailer: %w: WriteHeader not called", ErrOutOfOrder)
+	}
+	for _, s := range output.streams {
+		if err := output.flushStream(s); err != nil {
+			return err
+		}
+	}
+	return ffmpeg.AVFormat_av_write_trailer(output.ctx)
+}
+
+// Seek is not supported on output media - seeking only applies to media
+// opened for reading
+func (output *output) Seek(Stream, time.Duration, SeekFlag) error {
+	return errors.New("Seek: not supported on output media")
+}
+
+// Duration is not meaningful for output media until the trailer has been
+// written, so it always returns zero
+func (output *output) Duration() time.Duration {
+	return 0
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// writePackets drains packets already encoded for the stream, rescaling
+// timestamps from the codec to the stream timebase and writing each to
+// the muxer, until the codec reports EAGAIN (needs more frames) or EOF
+// (fully flushed)
+func (output *output) writePackets(impl *stream) error {
+	for {
+		pkt, err := ffmpeg.AVCodec_avcodec_receive_packet(impl.codec)
+		if errors.Is(err, ffmpeg.EAGAIN) || errors.Is(err, ffmpeg.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		ffmpeg.AVCodec_av_packet_rescale_ts(pkt, impl.codec.TimeBase(), impl.ctx.TimeBase())
+		if err := ffmpeg.AVFormat_av_interleaved_write_frame(output.ctx, pkt); err != nil {
+			return err
+		}
+	}
+}
+
+// flushStream sends a nil frame to the stream's encoder, as required to
+// drain any packets buffered internally (for example B-frame reordering)
+// before the trailer is written
+func (output *output) flushStream(s *stream) error {
+	if err := ffmpeg.AVCodec_avcodec_send_frame(s.codec, nil); err != nil {
+		return fmt.Errorf("WriteTrailer: %w", err)
+	}
+	return output.writePackets(s)
+}
+
+// videoTimeBase derives the codec timebase from the stream's frame rate,
+// so that av_packet_rescale_ts has a meaningful source timebase to work
+// from. Falls back to a common 25fps timebase if no rate was given
+func videoTimeBase(params *VideoParams) (num, den int) {
+	if params.FrameRate > 0 {
+		return 1, int(params.FrameRate)
+	}
+	return 1, 25
+}
+
+// audioTimeBase derives the codec timebase from the stream's sample rate
+func audioTimeBase(params *AudioParams) (num, den int) {
+	if params.Format.Rate > 0 {
+		return 1, int(params.Format.Rate)
+	}
+	return 1, 1
+}
+
+func toAVVideoParams(params *VideoParams) ffmpeg.AVVideoParams {
+	return ffmpeg.AVVideoParams{
+		Width:     params.Width,
+		Height:    params.Height,
+		PixFmt:    ffmpeg.AVPixelFormat(params.PixelFormat),
+		FrameRate: params.FrameRate,
+		BitRate:   params.BitRate,
+		GOPSize:   params.GOPSize,
+		Profile:   params.Profile,
+		Level:     params.Level,
+	}
+}
+
+func toAVAudioParams(params *AudioParams) ffmpeg.AVAudioParams {
+	return ffmpeg.AVAudioParams{
+		SampleRate: int(params.Format.Rate),
+		SampleFmt:  ffmpeg.AVSampleFormat(params.Format.Format),
+		ChLayout:   ffmpeg.AVChannelLayout(params.Format.Layout),
+		BitRate:    params.BitRate,
+	}
+}
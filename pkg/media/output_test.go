@@ -0,0 +1,35 @@
+package media
+
+import (
+	"testing"
+
+	// Packages
+	assert "github.com/stretchr/testify/assert"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+func Test_output_000(t *testing.T) {
+	assert := assert.New(t)
+
+	num, den := videoTimeBase(&VideoParams{FrameRate: 30})
+	assert.Equal(1, num)
+	assert.Equal(30, den)
+
+	num, den = videoTimeBase(&VideoParams{})
+	assert.Equal(1, num)
+	assert.Equal(25, den)
+}
+
+func Test_output_001(t *testing.T) {
+	assert := assert.New(t)
+
+	num, den := audioTimeBase(&AudioParams{Format: AudioFormat{Rate: 48000}})
+	assert.Equal(1, num)
+	assert.Equal(48000, den)
+
+	num, den = audioTimeBase(&AudioParams{})
+	assert.Equal(1, num)
+	assert.Equal(1, den)
+}
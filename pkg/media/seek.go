@@ -0,0 +1,85 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	multierror "github.com/hashicorp/go-multierror"
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/djthorpe/go-errors"
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+// Seek seeks the given stream to the target position, backed by
+// av_seek_frame. Callers should call Map.Flush afterwards, to avoid
+// decoding corruption
+func (in *input) Seek(s Stream, target time.Duration, flags SeekFlag) error {
+	impl, ok := s.(*stream)
+	if !ok || impl == nil {
+		return ErrBadParameter.With("stream")
+	}
+	return ffmpeg.AVFormat_av_seek_frame(in.ctx, impl.Index(), seekTimestamp(impl, target, flags), avSeekFlags(flags))
+}
+
+// Duration returns the duration of the media, or zero if unknown
+func (in *input) Duration() time.Duration {
+	return ffmpeg.AVFormat_duration(in.ctx)
+}
+
+// Flush calls avcodec_flush_buffers on every decoder context opened for the
+// mapped streams, as is required after a Media.Seek to avoid decoding
+// corruption
+func (d *decodemap) Flush() error {
+	var result error
+	for _, s := range d.Streams() {
+		impl, ok := s.(*stream)
+		if !ok || impl == nil || impl.codec == nil {
+			continue
+		}
+		if err := ffmpeg.AVCodec_avcodec_flush_buffers(impl.codec); err != nil {
+			result = multierror.Append(result, err)
+		}
+	}
+	return result
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// seekTimestamp converts target into the unit expected by av_seek_frame for
+// the given flags: a byte offset, a frame number, or a stream-timebase
+// timestamp
+func seekTimestamp(stream *stream, target time.Duration, flags SeekFlag) int64 {
+	if flags.Is(SEEK_BYTE) {
+		return int64(target)
+	}
+	num, den := stream.TimeBase()
+	if flags.Is(SEEK_FRAME) || num == 0 {
+		return int64(target)
+	}
+	return int64(target) * int64(den) / (int64(num) * int64(time.Second))
+}
+
+// avSeekFlags maps a SeekFlag to the AVSEEK_FLAG_* bitfield used by
+// av_seek_frame
+func avSeekFlags(flags SeekFlag) int {
+	var result int
+	if flags.Is(SEEK_BACKWARD) {
+		result |= ffmpeg.AVSEEK_FLAG_BACKWARD
+	}
+	if flags.Is(SEEK_ANY) {
+		result |= ffmpeg.AVSEEK_FLAG_ANY
+	}
+	if flags.Is(SEEK_BYTE) {
+		result |= ffmpeg.AVSEEK_FLAG_BYTE
+	}
+	if flags.Is(SEEK_FRAME) {
+		result |= ffmpeg.AVSEEK_FLAG_FRAME
+	}
+	return result
+}
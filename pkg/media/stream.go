@@ -0,0 +1,155 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type stream struct {
+	ctx      *ffmpeg.AVStream
+	codec    *ffmpeg.AVCodecContext
+	flags    MediaFlag
+	hwDevice *ffmpeg.AVBufferRef // set by decodemap.SetHWDevice, applied when the decoder is opened
+}
+
+// Ensure stream complies with Stream interface
+var _ Stream = (*stream)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (stream *stream) Index() int {
+	return ffmpeg.AVStream_index(stream.ctx)
+}
+
+func (stream *stream) Flags() MediaFlag {
+	return stream.flags
+}
+
+func (stream *stream) Artwork() []byte {
+	if !stream.flags.Is(MEDIA_FLAG_ARTWORK) {
+		return nil
+	}
+	return ffmpeg.AVStream_attached_pic(stream.ctx)
+}
+
+func (stream *stream) Duration() time.Duration {
+	num, den := stream.TimeBase()
+	if den == 0 {
+		return 0
+	}
+	return time.Duration(ffmpeg.AVStream_duration(stream.ctx)) * time.Duration(num) * time.Second / time.Duration(den)
+}
+
+func (stream *stream) TimeBase() (int, int) {
+	return ffmpeg.AVStream_time_base(stream.ctx)
+}
+
+func (stream *stream) Disposition() StreamDisposition {
+	return dispositionFromAV(ffmpeg.AVStream_disposition(stream.ctx))
+}
+
+// Rotation returns the display matrix rotation angle, in degrees, from the
+// AV_PKT_DATA_DISPLAY_MATRIX side-data, or zero if not present
+func (stream *stream) Rotation() float64 {
+	matrix, ok := ffmpeg.AVStream_display_matrix(stream.ctx)
+	if !ok {
+		return 0
+	}
+	return ffmpeg.AVUtil_av_display_rotation_get(matrix)
+}
+
+// Stereo3D returns the stereoscopic layout of the stream, from the
+// AV_PKT_DATA_STEREO3D side-data, or STEREO3D_NONE if not present
+func (stream *stream) Stereo3D() Stereo3DMode {
+	mode, ok := ffmpeg.AVStream_stereo3d_type(stream.ctx)
+	if !ok {
+		return STEREO3D_NONE
+	}
+	switch mode {
+	case ffmpeg.AV_STEREO3D_SIDEBYSIDE:
+		return STEREO3D_SIDE_BY_SIDE
+	case ffmpeg.AV_STEREO3D_TOPBOTTOM:
+		return STEREO3D_TOP_BOTTOM
+	case ffmpeg.AV_STEREO3D_FRAMESEQUENCE:
+		return STEREO3D_FRAME_SEQUENCE
+	default:
+		return STEREO3D_NONE
+	}
+}
+
+// Spherical returns the spherical (360) projection of the stream, from the
+// AV_PKT_DATA_SPHERICAL side-data, or SPHERICAL_NONE if not present
+func (stream *stream) Spherical() SphericalProjection {
+	projection, ok := ffmpeg.AVStream_spherical_projection(stream.ctx)
+	if !ok {
+		return SPHERICAL_NONE
+	}
+	switch projection {
+	case ffmpeg.AV_SPHERICAL_EQUIRECTANGULAR:
+		return SPHERICAL_EQUIRECTANGULAR
+	case ffmpeg.AV_SPHERICAL_CUBEMAP:
+		return SPHERICAL_CUBEMAP
+	default:
+		return SPHERICAL_NONE
+	}
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// newStreams builds the stream list for an already-opened input format
+// context, for example one opened via a custom AVIOContext
+func newStreams(ctx *ffmpeg.AVFormatContext) []*stream {
+	streams := ffmpeg.AVFormat_streams(ctx)
+	result := make([]*stream, len(streams))
+	for i, avstream := range streams {
+		result[i] = &stream{ctx: avstream, flags: flagsForCodecType(ffmpeg.AVStream_codec_type(avstream))}
+	}
+	return result
+}
+
+// dispositionFromAV maps the AV_DISPOSITION_* bitfield to StreamDisposition
+func dispositionFromAV(d ffmpeg.AVDisposition) StreamDisposition {
+	var result StreamDisposition
+	if d&ffmpeg.AV_DISPOSITION_DEFAULT != 0 {
+		result |= DISPOSITION_DEFAULT
+	}
+	if d&ffmpeg.AV_DISPOSITION_FORCED != 0 {
+		result |= DISPOSITION_FORCED
+	}
+	if d&ffmpeg.AV_DISPOSITION_HEARING_IMPAIRED != 0 {
+		result |= DISPOSITION_HEARING_IMPAIRED
+	}
+	if d&ffmpeg.AV_DISPOSITION_ATTACHED_PIC != 0 {
+		result |= DISPOSITION_ATTACHED_PIC
+	}
+	if d&ffmpeg.AV_DISPOSITION_CAPTIONS != 0 {
+		result |= DISPOSITION_CAPTIONS
+	}
+	if d&ffmpeg.AV_DISPOSITION_LYRICS != 0 {
+		result |= DISPOSITION_LYRICS
+	}
+	return result
+}
+
+func flagsForCodecType(t ffmpeg.AVMediaType) MediaFlag {
+	switch t {
+	case ffmpeg.AVMEDIA_TYPE_VIDEO:
+		return MEDIA_FLAG_VIDEO
+	case ffmpeg.AVMEDIA_TYPE_AUDIO:
+		return MEDIA_FLAG_AUDIO
+	case ffmpeg.AVMEDIA_TYPE_SUBTITLE:
+		return MEDIA_FLAG_SUBTITLE
+	default:
+		return MEDIA_FLAG_DATA
+	}
+}
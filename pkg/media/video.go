@@ -0,0 +1,91 @@
+package media
+
+import (
+	"time"
+
+	// Packages
+	ffmpeg "github.com/mutablelogic/go-media/sys/ffmpeg51"
+
+	// Namespace imports
+	. "github.com/mutablelogic/go-media"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// TYPES
+
+type videoframe struct {
+	ctx    *ffmpeg.AVFrame
+	stream Stream
+	pts    time.Duration
+}
+
+// Ensure videoframe complies with VideoFrame interface
+var _ VideoFrame = (*videoframe)(nil)
+
+////////////////////////////////////////////////////////////////////////////////
+// LIFECYCLE
+
+func newVideoFrame(ctx *ffmpeg.AVFrame, stream Stream, pts time.Duration) *videoframe {
+	return &videoframe{ctx: ctx, stream: stream, pts: pts}
+}
+
+// Release frees the underlying AVFrame, once the decode callback for it
+// has returned
+func (frame *videoframe) Release() error {
+	if frame.ctx != nil {
+		ffmpeg.AVUtil_av_frame_free(frame.ctx)
+		frame.ctx = nil
+	}
+	return nil
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PUBLIC METHODS
+
+func (frame *videoframe) Stream() Stream {
+	return frame.stream
+}
+
+func (frame *videoframe) PTS() time.Duration {
+	return frame.pts
+}
+
+func (frame *videoframe) Width() int {
+	return ffmpeg.AVUtil_av_frame_width(frame.ctx)
+}
+
+func (frame *videoframe) Height() int {
+	return ffmpeg.AVUtil_av_frame_height(frame.ctx)
+}
+
+func (frame *videoframe) PixelFormat() PixelFormat {
+	return PixelFormat(ffmpeg.AVUtil_av_frame_pix_fmt(frame.ctx))
+}
+
+func (frame *videoframe) Planes() [][]byte {
+	n := ffmpeg.AVUtil_av_frame_num_planes(frame.ctx)
+	planes := make([][]byte, n)
+	for i := range planes {
+		planes[i] = ffmpeg.AVUtil_av_frame_data(frame.ctx, i)
+	}
+	return planes
+}
+
+func (frame *videoframe) Stride(plane int) int {
+	return ffmpeg.AVUtil_av_frame_linesize(frame.ctx, plane)
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// PRIVATE METHODS
+
+// avframe returns the underlying AVFrame, for internal use by the encode
+// and fragment-write paths which need to pass it to cgo calls directly
+func (frame *videoframe) avframe() *ffmpeg.AVFrame {
+	return frame.ctx
+}
+
+// isKeyFrame reports whether the frame is a key (intra) frame, used to
+// detect fragment boundaries in fragmentedOutput.WriteFrame
+func (frame *videoframe) isKeyFrame() bool {
+	return ffmpeg.AVUtil_av_frame_key_frame(frame.ctx)
+}
@@ -0,0 +1,56 @@
+package media
+
+// SeekFlag controls how the target passed to Media.Seek is interpreted
+type SeekFlag uint
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	SEEK_BACKWARD SeekFlag = (1 << iota) // Seek to the nearest keyframe before target
+	SEEK_ANY                             // Allow seeking to a non-keyframe
+	SEEK_BYTE                            // Target is interpreted as a byte offset
+	SEEK_FRAME                           // Target is interpreted as a frame number, using the stream timebase
+	SEEK_NONE SeekFlag = 0
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// STRINGIFY
+
+func (f SeekFlag) String() string {
+	if f == SEEK_NONE {
+		return "SEEK_NONE"
+	}
+	str := ""
+	for v := SeekFlag(1); v <= SEEK_FRAME; v <<= 1 {
+		if f&v == v {
+			str += "|" + f.flagString(v)
+		}
+	}
+	if str == "" {
+		return "[?? Invalid SeekFlag value]"
+	}
+	return str[1:]
+}
+
+////////////////////////////////////////////////////////////////////////////////
+// METHODS
+
+func (f SeekFlag) Is(v SeekFlag) bool {
+	return f&v == v
+}
+
+func (f SeekFlag) flagString(v SeekFlag) string {
+	switch v {
+	case SEEK_BACKWARD:
+		return "SEEK_BACKWARD"
+	case SEEK_ANY:
+		return "SEEK_ANY"
+	case SEEK_BYTE:
+		return "SEEK_BYTE"
+	case SEEK_FRAME:
+		return "SEEK_FRAME"
+	default:
+		return "[?? Invalid SeekFlag value]"
+	}
+}
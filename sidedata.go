@@ -0,0 +1,25 @@
+package media
+
+// Stereo3DMode describes the stereoscopic layout of a video stream, from
+// AV_PKT_DATA_STEREO3D side-data
+type Stereo3DMode string
+
+// SphericalProjection describes the spherical (360) projection of a video
+// stream, from AV_PKT_DATA_SPHERICAL side-data
+type SphericalProjection string
+
+////////////////////////////////////////////////////////////////////////////////
+// CONSTANTS
+
+const (
+	STEREO3D_NONE           Stereo3DMode = ""
+	STEREO3D_SIDE_BY_SIDE   Stereo3DMode = "side_by_side"
+	STEREO3D_TOP_BOTTOM     Stereo3DMode = "top_bottom"
+	STEREO3D_FRAME_SEQUENCE Stereo3DMode = "frame_sequence"
+)
+
+const (
+	SPHERICAL_NONE            SphericalProjection = ""
+	SPHERICAL_EQUIRECTANGULAR SphericalProjection = "equirectangular"
+	SPHERICAL_CUBEMAP         SphericalProjection = "cubemap"
+)
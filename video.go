@@ -0,0 +1,23 @@
+package media
+
+////////////////////////////////////////////////////////////////////////////////
+// INTERFACES
+
+// VideoFrame is a single decoded or to-be-encoded video frame
+type VideoFrame interface {
+	Frame
+
+	// Width and Height of the frame, in pixels
+	Width() int
+	Height() int
+
+	// PixelFormat of the frame
+	PixelFormat() PixelFormat
+
+	// Planes returns the raw bytes for each image plane. For packed pixel
+	// formats, there is a single plane
+	Planes() [][]byte
+
+	// Stride returns the linesize, in bytes, for the given plane
+	Stride(plane int) int
+}